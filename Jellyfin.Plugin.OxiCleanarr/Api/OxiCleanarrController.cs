@@ -22,6 +22,23 @@ namespace Jellyfin.Plugin.OxiCleanarr.Api;
 [Produces(MediaTypeNames.Application.Json)]
 public class OxiCleanarrController : ControllerBase
 {
+    /// <summary>
+    /// Features supported by this plugin version, advertised by the status endpoint.
+    /// </summary>
+    private static readonly string[] SupportedCapabilities =
+    {
+        "symlinks_add",
+        "symlinks_remove",
+        "symlinks_list",
+        "symlink_names",
+        "directories_create",
+        "directories_remove",
+        "per_item_target_directory",
+        "symlink_only_removal",
+        "allowed_roots",
+        "allowed_source_roots"
+    };
+
     private readonly ILogger<OxiCleanarrController> _logger;
     private readonly SymlinkManager _symlinkManager;
 
@@ -175,6 +192,11 @@ public class OxiCleanarrController : ControllerBase
                 Message = message
             });
         }
+        catch (ArgumentException ex)
+        {
+            _logger.LogWarning(ex, "Invalid directory path: {Directory}", directory);
+            return BadRequest(new { error = ex.Message });
+        }
         catch (Exception ex)
         {
             _logger.LogError(ex, "Failed to list symlinks in directory: {Directory}", directory);
@@ -214,6 +236,11 @@ public class OxiCleanarrController : ControllerBase
                 Message = message
             });
         }
+        catch (ArgumentException ex)
+        {
+            _logger.LogWarning(ex, "Invalid directory path: {Directory}", request.Directory);
+            return BadRequest(new { error = ex.Message });
+        }
         catch (Exception ex)
         {
             _logger.LogError(ex, "Failed to create directory: {Directory}", request.Directory);
@@ -251,9 +278,14 @@ public class OxiCleanarrController : ControllerBase
                 Message = "Directory removed successfully"
             });
         }
+        catch (ArgumentException ex)
+        {
+            _logger.LogWarning(ex, "Invalid directory path: {Directory}", request.Directory);
+            return BadRequest(new { error = ex.Message });
+        }
         catch (InvalidOperationException ex)
         {
-            _logger.LogWarning(ex, "Cannot remove non-empty directory: {Directory}", request.Directory);
+            _logger.LogWarning(ex, "Cannot remove directory: {Directory}", request.Directory);
             return BadRequest(new { error = ex.Message });
         }
         catch (Exception ex)
@@ -264,7 +296,7 @@ public class OxiCleanarrController : ControllerBase
     }
 
     /// <summary>
-    /// Gets plugin status and version.
+    /// Gets plugin status, version and capabilities.
     /// </summary>
     /// <returns>Plugin status.</returns>
     [HttpGet("status")]
@@ -274,7 +306,8 @@ public class OxiCleanarrController : ControllerBase
     {
         return Ok(new StatusResponse
         {
-            Version = Plugin.Instance?.Version.ToString() ?? "unknown"
+            Version = Plugin.Instance?.Version.ToString() ?? "unknown",
+            Capabilities = SupportedCapabilities
         });
     }
 }
@@ -379,6 +412,11 @@ public class StatusResponse
     /// Gets or sets the plugin version.
     /// </summary>
     public string Version { get; set; } = string.Empty;
+
+    /// <summary>
+    /// Gets or sets the features supported by the plugin, for client feature detection.
+    /// </summary>
+    public string[] Capabilities { get; set; } = Array.Empty<string>();
 }
 
 /// <summary>
@@ -1,12 +1,23 @@
+using System;
 using MediaBrowser.Model.Plugins;
 
 namespace Jellyfin.Plugin.OxiCleanarr.Configuration;
 
 /// <summary>
 /// Plugin configuration.
-/// Note: v2.0+ has no configuration - all paths are provided via API requests.
+/// Symlink and directory paths are still provided via API requests; the configuration only limits where they may point.
 /// </summary>
 public class PluginConfiguration : BasePluginConfiguration
 {
-    // Empty configuration - plugin is now stateless and configuration-free
+    /// <summary>
+    /// Gets or sets the directories the plugin may create, list and remove symlinks and directories in.
+    /// Requests for paths outside these roots (after resolving symlinks) are rejected. When empty, every request is rejected.
+    /// </summary>
+    public string[] AllowedRoots { get; set; } = Array.Empty<string>();
+
+    /// <summary>
+    /// Gets or sets the media directories symlinks may point into.
+    /// Add requests whose source resolves outside these roots are rejected. When empty, any source is accepted.
+    /// </summary>
+    public string[] AllowedSourceRoots { get; set; } = Array.Empty<string>();
 }
@@ -1,7 +1,9 @@
 using System;
 using System.IO;
+using System.Linq;
 using System.Threading;
 using System.Threading.Tasks;
+using Jellyfin.Plugin.OxiCleanarr.Configuration;
 using MediaBrowser.Controller.Library;
 using Microsoft.Extensions.Logging;
 
@@ -12,6 +14,13 @@ namespace Jellyfin.Plugin.OxiCleanarr.Services;
 /// </summary>
 public class SymlinkManager
 {
+    /// <summary>
+    /// Maximum number of nested symlinks followed while resolving a path, matching Linux's MAXSYMLINKS.
+    /// </summary>
+    private const int MaxSymlinkDepth = 40;
+
+    private static readonly char[] PathSeparators = { Path.DirectorySeparatorChar, Path.AltDirectorySeparatorChar };
+
     private readonly ILogger<SymlinkManager> _logger;
     private readonly ILibraryManager _libraryManager;
 
@@ -26,17 +35,20 @@ public class SymlinkManager
         _libraryManager = libraryManager;
     }
 
+    /// <summary>
+    /// Gets the current plugin configuration.
+    /// </summary>
+    private static PluginConfiguration Configuration => Plugin.Instance?.Configuration ?? new PluginConfiguration();
+
     /// <summary>
     /// Ensures a directory exists, creating it if necessary.
     /// </summary>
     /// <param name="directoryPath">The directory path to ensure exists.</param>
     /// <returns>True if directory was created, false if it already existed.</returns>
+    /// <exception cref="ArgumentException">Thrown when the path is empty, relative, contains ".." or resolves outside the allowed roots.</exception>
     public bool EnsureDirectoryExists(string directoryPath)
     {
-        if (string.IsNullOrWhiteSpace(directoryPath))
-        {
-            throw new ArgumentException("Directory path cannot be empty", nameof(directoryPath));
-        }
+        ValidatePath(directoryPath, nameof(directoryPath));
 
         if (Directory.Exists(directoryPath))
         {
@@ -57,9 +69,13 @@ public class SymlinkManager
     /// <param name="targetDirectory">The target directory for the symlink.</param>
     /// <param name="cancellationToken">Cancellation token.</param>
     /// <returns>The path to the created symlink.</returns>
+    /// <exception cref="ArgumentException">Thrown when a path is empty, relative, contains "..", or resolves outside the allowed roots (target) or allowed source roots (source).</exception>
     public Task<string> CreateSymlinkAsync(string sourcePath, string targetDirectory, CancellationToken cancellationToken = default)
     {
         _ = cancellationToken; // Reserved for future use
+        ValidateSourcePath(sourcePath, nameof(sourcePath));
+        ValidatePath(targetDirectory, nameof(targetDirectory));
+
         if (!File.Exists(sourcePath))
         {
             throw new FileNotFoundException($"Source file not found: {sourcePath}");
@@ -71,9 +87,14 @@ public class SymlinkManager
         var fileName = Path.GetFileName(sourcePath);
         var symlinkPath = Path.Combine(targetDirectory, fileName);
 
-        // If symlink already exists, remove it
+        // If symlink already exists, remove it (never overwrite a regular file)
         if (File.Exists(symlinkPath))
         {
+            if (!IsSymlink(symlinkPath))
+            {
+                throw new InvalidOperationException($"A regular file already exists at {symlinkPath}");
+            }
+
             _logger.LogInformation("Removing existing symlink: {Path}", symlinkPath);
             File.Delete(symlinkPath);
         }
@@ -99,14 +120,28 @@ public class SymlinkManager
     /// Removes a symlink.
     /// </summary>
     /// <param name="symlinkPath">The symlink path to remove.</param>
+    /// <exception cref="ArgumentException">Thrown when the path is empty, relative, contains ".." or resolves outside the allowed roots.</exception>
+    /// <exception cref="InvalidOperationException">Thrown when the path exists but is not a symlink.</exception>
     public void RemoveSymlink(string symlinkPath)
     {
-        if (!File.Exists(symlinkPath))
+        ValidatePath(symlinkPath, nameof(symlinkPath), followLink: false);
+
+        if (Directory.Exists(symlinkPath) && !IsSymlink(symlinkPath))
+        {
+            throw new InvalidOperationException($"Path is a directory, not a symlink: {symlinkPath}");
+        }
+
+        if (!File.Exists(symlinkPath) && !Directory.Exists(symlinkPath))
         {
             _logger.LogWarning("Symlink does not exist: {Path}", symlinkPath);
             return;
         }
 
+        if (!IsSymlink(symlinkPath))
+        {
+            throw new InvalidOperationException($"Path is not a symlink: {symlinkPath}");
+        }
+
         _logger.LogInformation("Removing symlink: {Path}", symlinkPath);
         File.Delete(symlinkPath);
         _logger.LogInformation("Successfully removed symlink: {Path}", symlinkPath);
@@ -117,12 +152,15 @@ public class SymlinkManager
     /// </summary>
     /// <param name="directoryPath">The directory path to remove.</param>
     /// <param name="force">If true, removes directory even if not empty. If false, only removes if empty.</param>
-    /// <exception cref="InvalidOperationException">Thrown when directory is not empty and force is false.</exception>
+    /// <exception cref="ArgumentException">Thrown when the path is empty, relative, contains ".." or resolves outside the allowed roots.</exception>
+    /// <exception cref="InvalidOperationException">Thrown when the directory is an allowed root, or is not empty and force is false.</exception>
     public void RemoveDirectory(string directoryPath, bool force = false)
     {
-        if (string.IsNullOrWhiteSpace(directoryPath))
+        ValidatePath(directoryPath, nameof(directoryPath));
+
+        if (IsAllowedRoot(directoryPath))
         {
-            throw new ArgumentException("Directory path cannot be empty", nameof(directoryPath));
+            throw new InvalidOperationException($"Cannot remove an allowed root: {directoryPath}");
         }
 
         if (!Directory.Exists(directoryPath))
@@ -150,8 +188,11 @@ public class SymlinkManager
     /// Clears all symlinks in a directory.
     /// </summary>
     /// <param name="directory">The directory to clear.</param>
+    /// <exception cref="ArgumentException">Thrown when the path is empty, relative, contains ".." or resolves outside the allowed roots.</exception>
     public void ClearSymlinks(string directory)
     {
+        ValidatePath(directory, nameof(directory));
+
         if (!Directory.Exists(directory))
         {
             _logger.LogWarning("Directory does not exist: {Directory}", directory);
@@ -165,7 +206,7 @@ public class SymlinkManager
         foreach (var file in files)
         {
             var fileInfo = new FileInfo(file);
-            if (fileInfo.Attributes.HasFlag(FileAttributes.ReparsePoint))
+            if (IsSymlink(fileInfo))
             {
                 File.Delete(file);
                 removedCount++;
@@ -181,8 +222,11 @@ public class SymlinkManager
     /// </summary>
     /// <param name="directory">The directory to list symlinks from.</param>
     /// <returns>An array of symlink information including path and target.</returns>
+    /// <exception cref="ArgumentException">Thrown when the path is empty, relative, contains ".." or resolves outside the allowed roots.</exception>
     public SymlinkInfo[] ListSymlinks(string directory)
     {
+        ValidatePath(directory, nameof(directory));
+
         if (!Directory.Exists(directory))
         {
             _logger.LogWarning("Directory does not exist: {Directory}", directory);
@@ -197,7 +241,7 @@ public class SymlinkManager
         foreach (var file in files)
         {
             var fileInfo = new FileInfo(file);
-            if (fileInfo.Attributes.HasFlag(FileAttributes.ReparsePoint))
+            if (IsSymlink(fileInfo))
             {
                 try
                 {
@@ -219,6 +263,197 @@ public class SymlinkManager
         _logger.LogInformation("Found {Count} symlink(s) in directory: {Directory}", symlinks.Count, directory);
         return symlinks.ToArray();
     }
+
+    /// <summary>
+    /// Validates that a path is well formed and resolves inside one of the configured allowed roots.
+    /// </summary>
+    /// <param name="path">The path to validate.</param>
+    /// <param name="paramName">The name of the parameter being validated.</param>
+    /// <param name="followLink">Whether to resolve the last segment when it is a symlink; false checks where the link itself lives.</param>
+    /// <exception cref="ArgumentException">Thrown when the path is empty, relative, contains ".." or resolves outside the allowed roots.</exception>
+    private static void ValidatePath(string path, string paramName, bool followLink = true)
+    {
+        ValidatePathSyntax(path, paramName);
+
+        var roots = ResolveRoots(Configuration.AllowedRoots);
+        if (roots.Length == 0)
+        {
+            throw new ArgumentException("No allowed roots are configured; add the Leaving Soon directories in the plugin settings", paramName);
+        }
+
+        var resolved = followLink ? ResolvePath(path) : ResolveParent(path);
+        if (!roots.Any(root => IsUnderRoot(resolved, root)))
+        {
+            throw new ArgumentException($"Path is outside the allowed roots: {path}", paramName);
+        }
+    }
+
+    /// <summary>
+    /// Validates that a source path is well formed and, when source roots are configured, resolves inside one of them.
+    /// </summary>
+    /// <param name="path">The source path to validate.</param>
+    /// <param name="paramName">The name of the parameter being validated.</param>
+    /// <exception cref="ArgumentException">Thrown when the path is empty, relative, contains ".." or resolves outside the allowed source roots.</exception>
+    private static void ValidateSourcePath(string path, string paramName)
+    {
+        ValidatePathSyntax(path, paramName);
+
+        var roots = ResolveRoots(Configuration.AllowedSourceRoots);
+        if (roots.Length == 0)
+        {
+            return;
+        }
+
+        var resolved = ResolvePath(path);
+        if (!roots.Any(root => IsUnderRoot(resolved, root)))
+        {
+            throw new ArgumentException($"Source path is outside the allowed source roots: {path}", paramName);
+        }
+    }
+
+    /// <summary>
+    /// Checks whether a directory resolves to one of the configured allowed roots itself.
+    /// </summary>
+    /// <param name="directoryPath">The directory path to check.</param>
+    /// <returns>True if the directory is an allowed root.</returns>
+    private static bool IsAllowedRoot(string directoryPath)
+    {
+        var resolved = ResolvePath(directoryPath);
+        return ResolveRoots(Configuration.AllowedRoots).Contains(resolved, StringComparer.Ordinal);
+    }
+
+    /// <summary>
+    /// Resolves the configured roots, skipping entries that are not absolute paths.
+    /// </summary>
+    /// <param name="roots">The configured roots.</param>
+    /// <returns>The roots with all symlinks resolved.</returns>
+    private static string[] ResolveRoots(string[] roots)
+    {
+        return roots
+            .Select(root => root.Trim())
+            .Where(root => Path.IsPathFullyQualified(root))
+            .Select(root => ResolvePath(root))
+            .ToArray();
+    }
+
+    /// <summary>
+    /// Normalises a path and resolves every symlink along it, including the last segment.
+    /// Segments that do not exist yet are kept as they are.
+    /// </summary>
+    /// <param name="path">The absolute path to resolve.</param>
+    /// <param name="depth">The number of symlinks followed so far.</param>
+    /// <returns>The resolved path.</returns>
+    /// <exception cref="IOException">Thrown when more than <see cref="MaxSymlinkDepth"/> symlinks are nested.</exception>
+    private static string ResolvePath(string path, int depth = 0)
+    {
+        var fullPath = Path.GetFullPath(path);
+        var resolved = Path.GetPathRoot(fullPath) ?? string.Empty;
+
+        foreach (var segment in fullPath.Substring(resolved.Length).Split(PathSeparators, StringSplitOptions.RemoveEmptyEntries))
+        {
+            resolved = Path.Combine(resolved, segment);
+
+            // LinkTarget is null for regular entries and for paths that do not exist
+            var linkTarget = new FileInfo(resolved).LinkTarget;
+            if (linkTarget is null)
+            {
+                continue;
+            }
+
+            if (depth >= MaxSymlinkDepth)
+            {
+                throw new IOException($"Too many levels of symbolic links: {path}");
+            }
+
+            var linkDirectory = Path.GetDirectoryName(resolved) ?? resolved;
+            resolved = ResolvePath(Path.Combine(linkDirectory, linkTarget), depth + 1);
+        }
+
+        return resolved;
+    }
+
+    /// <summary>
+    /// Resolves the parent directory of a path but keeps the last segment, so a symlink is located rather than followed.
+    /// </summary>
+    /// <param name="path">The absolute path to resolve.</param>
+    /// <returns>The path with its parent directory resolved.</returns>
+    private static string ResolveParent(string path)
+    {
+        var fullPath = Path.GetFullPath(path);
+        var parent = Path.GetDirectoryName(fullPath);
+        return parent is null ? fullPath : Path.Combine(ResolvePath(parent), Path.GetFileName(fullPath));
+    }
+
+    /// <summary>
+    /// Checks whether a resolved path is a root or lies below it.
+    /// </summary>
+    /// <param name="path">The resolved path.</param>
+    /// <param name="root">The resolved root.</param>
+    /// <returns>True if the path is inside the root.</returns>
+    private static bool IsUnderRoot(string path, string root)
+    {
+        var relative = Path.GetRelativePath(root, path);
+        if (relative == ".")
+        {
+            return true;
+        }
+
+        return relative != ".."
+            && !relative.StartsWith(".." + Path.DirectorySeparatorChar, StringComparison.Ordinal)
+            && !Path.IsPathFullyQualified(relative);
+    }
+
+    /// <summary>
+    /// Validates that a path is absolute and contains no parent directory segments.
+    /// </summary>
+    /// <param name="path">The path to validate.</param>
+    /// <param name="paramName">The name of the parameter being validated.</param>
+    /// <exception cref="ArgumentException">Thrown when the path is empty, relative or contains "..".</exception>
+    private static void ValidatePathSyntax(string path, string paramName)
+    {
+        if (string.IsNullOrWhiteSpace(path))
+        {
+            throw new ArgumentException("Path cannot be empty", paramName);
+        }
+
+        if (path.Contains('\0', StringComparison.Ordinal))
+        {
+            throw new ArgumentException($"Path contains invalid characters: {path}", paramName);
+        }
+
+        if (!Path.IsPathFullyQualified(path))
+        {
+            throw new ArgumentException($"Path must be absolute: {path}", paramName);
+        }
+
+        foreach (var segment in path.Split(PathSeparators))
+        {
+            if (segment == "..")
+            {
+                throw new ArgumentException($"Path must not contain '..' segments: {path}", paramName);
+            }
+        }
+    }
+
+    /// <summary>
+    /// Checks whether a path is a symlink (without following it).
+    /// </summary>
+    /// <param name="path">The path to check.</param>
+    /// <returns>True if the path is a symlink.</returns>
+    private static bool IsSymlink(string path)
+    {
+        return IsSymlink(new FileInfo(path));
+    }
+
+    /// <summary>
+    /// Checks whether a file system entry is a symlink (without following it).
+    /// </summary>
+    /// <param name="info">The file system entry to check.</param>
+    /// <returns>True if the entry is a symlink.</returns>
+    private static bool IsSymlink(FileSystemInfo info)
+    {
+        return info.Attributes.HasFlag(FileAttributes.ReparsePoint);
+    }
 }
 
 /// <summary>
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 )
@@ -13,6 +14,8 @@ import (
 const (
 	DefaultMaxRetries = 60
 	DefaultRetryDelay = 2 * time.Second
+	// PluginID is the OxiCleanarr Bridge plugin GUID (Plugin.Id)
+	PluginID = "a8c0f8e4-7d3c-4b5a-9e2f-1a2b3c4d5e6f"
 )
 
 // JellyfinClient handles Jellyfin API interactions for testing
@@ -196,7 +199,9 @@ func (jc *JellyfinClient) Authenticate() error {
 func (jc *JellyfinClient) CreateAPIKey(appName string) (string, error) {
 	jc.t.Logf("Creating API key for %s...", appName)
 
-	req, _ := http.NewRequest("POST", jc.BaseURL+"/Auth/Keys?App="+appName, nil)
+	query := url.Values{}
+	query.Set("App", appName)
+	req, _ := http.NewRequest("POST", jc.BaseURL+"/Auth/Keys?"+query.Encode(), nil)
 	req.Header.Set("X-MediaBrowser-Token", jc.APIKey)
 
 	resp, err := jc.client.Do(req)
@@ -241,6 +246,29 @@ func (jc *JellyfinClient) CreateAPIKey(appName string) (string, error) {
 	return "", fmt.Errorf("API key not found after creation")
 }
 
+// GetServerVersion returns the Jellyfin server version from the public system info endpoint
+func (jc *JellyfinClient) GetServerVersion() (string, error) {
+	resp, err := jc.client.Get(jc.BaseURL + "/System/Info/Public")
+	if err != nil {
+		return "", fmt.Errorf("failed to query system info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get system info: status %d", resp.StatusCode)
+	}
+
+	var info struct {
+		Version string `json:"Version"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return "", fmt.Errorf("failed to decode system info response: %w", err)
+	}
+
+	return info.Version, nil
+}
+
 // SetupForTest performs complete Jellyfin setup for integration testing
 func SetupJellyfinForTest(t *testing.T, baseURL, username, password string) (*JellyfinClient, error) {
 	client := NewJellyfinClient(t, baseURL, username, password)
@@ -295,6 +323,13 @@ func (jc *JellyfinClient) DoRequest(method, path string, body interface{}) (*htt
 	return jc.client.Do(req)
 }
 
+// SymlinkListPath builds the plugin list endpoint path with the directory query-encoded
+func SymlinkListPath(directory string) string {
+	query := url.Values{}
+	query.Set("directory", directory)
+	return "/api/oxicleanarr/symlinks/list?" + query.Encode()
+}
+
 // GetInstalledPlugins queries the Jellyfin /Plugins endpoint to get all installed plugins
 func (jc *JellyfinClient) GetInstalledPlugins() ([]Plugin, error) {
 	resp, err := jc.DoRequest("GET", "/Plugins", nil)
@@ -316,6 +351,139 @@ func (jc *JellyfinClient) GetInstalledPlugins() ([]Plugin, error) {
 	return plugins, nil
 }
 
+// UpdatePluginConfiguration replaces the OxiCleanarr Bridge plugin configuration (allowed roots and source roots)
+func (jc *JellyfinClient) UpdatePluginConfiguration(config PluginConfiguration) error {
+	resp, err := jc.DoRequest("POST", "/Plugins/"+PluginID+"/Configuration", config)
+	if err != nil {
+		return fmt.Errorf("failed to update plugin configuration: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update plugin configuration: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// CreateVirtualFolder creates a Jellyfin library with a single path and triggers a scan
+func (jc *JellyfinClient) CreateVirtualFolder(name, collectionType, path string) error {
+	query := url.Values{}
+	query.Set("name", name)
+	query.Set("collectionType", collectionType)
+	query.Set("paths", path)
+	query.Set("refreshLibrary", "true")
+
+	resp, err := jc.DoRequest("POST", "/Library/VirtualFolders?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create virtual folder: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to create virtual folder: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// RemoveVirtualFolder deletes a Jellyfin library by name
+func (jc *JellyfinClient) RemoveVirtualFolder(name string) error {
+	query := url.Values{}
+	query.Set("name", name)
+
+	resp, err := jc.DoRequest("DELETE", "/Library/VirtualFolders?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove virtual folder: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to remove virtual folder: status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// GetVirtualFolderID returns the item ID of the Jellyfin library with the given name
+func (jc *JellyfinClient) GetVirtualFolderID(name string) (string, error) {
+	resp, err := jc.DoRequest("GET", "/Library/VirtualFolders", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to query virtual folders: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get virtual folders: status %d", resp.StatusCode)
+	}
+
+	var folders []struct {
+		Name   string `json:"Name"`
+		ItemID string `json:"ItemId"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&folders); err != nil {
+		return "", fmt.Errorf("failed to decode virtual folders response: %w", err)
+	}
+
+	for _, folder := range folders {
+		if folder.Name == name {
+			return folder.ItemID, nil
+		}
+	}
+
+	return "", fmt.Errorf("virtual folder %q not found", name)
+}
+
+// GetItems returns items of the given type below a parent (e.g. a library), recursively
+func (jc *JellyfinClient) GetItems(parentID, itemType string) ([]Item, error) {
+	query := url.Values{}
+	query.Set("userId", jc.UserID)
+	query.Set("parentId", parentID)
+	query.Set("includeItemTypes", itemType)
+	query.Set("recursive", "true")
+
+	resp, err := jc.DoRequest("GET", "/Items?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query items: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get items: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var itemsResponse struct {
+		Items []Item `json:"Items"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&itemsResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode items response: %w", err)
+	}
+
+	return itemsResponse.Items, nil
+}
+
+// Item represents a Jellyfin library item.
+// ParentIndexNumber (the season number for episodes) is nil when Jellyfin could not work it out.
+type Item struct {
+	Name              string `json:"Name"`
+	ID                string `json:"Id"`
+	Type              string `json:"Type"`
+	SeriesName        string `json:"SeriesName"`
+	IndexNumber       int    `json:"IndexNumber"`
+	ParentIndexNumber *int   `json:"ParentIndexNumber"`
+}
+
+// PluginConfiguration mirrors the OxiCleanarr Bridge plugin settings
+type PluginConfiguration struct {
+	AllowedRoots       []string `json:"AllowedRoots"`
+	AllowedSourceRoots []string `json:"AllowedSourceRoots"`
+}
+
 // Plugin represents a Jellyfin plugin
 type Plugin struct {
 	Name        string `json:"Name"`
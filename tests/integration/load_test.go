@@ -0,0 +1,258 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	// Load test media is generated on the host and mounted read-only into Jellyfin
+	HostLoadMediaDir      = "../assets/load-media"
+	ContainerLoadMediaDir = "/media/load"
+	DefaultLoadBatchSize  = 500
+	LoadRefreshTimeout    = 10 * time.Minute
+)
+
+// LoadTestResult is written as JSON so runs can be compared across commits
+type LoadTestResult struct {
+	JellyfinVersion      string  `json:"jellyfin_version"`
+	Items                int     `json:"items"`
+	BatchSize            int     `json:"batch_size"`
+	AddSeconds           float64 `json:"add_seconds"`
+	AddItemsPerSecond    float64 `json:"add_items_per_second"`
+	ListSeconds          float64 `json:"list_seconds"`
+	ListedItems          int     `json:"listed_items"`
+	RefreshSeconds       float64 `json:"refresh_seconds"`
+	RefreshedItems       int     `json:"refreshed_items"`
+	RefreshTimedOut      bool    `json:"refresh_timed_out"`
+	RemoveSeconds        float64 `json:"remove_seconds"`
+	RemoveItemsPerSecond float64 `json:"remove_items_per_second"`
+	Errors               int     `json:"errors"`
+}
+
+// envInt reads a positive integer from the environment, falling back to def
+func envInt(name string, def int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value <= 0 {
+		return def
+	}
+	return value
+}
+
+// loadItemCount returns OXICLEANARR_LOAD_ITEMS, or 0 when the load test is disabled
+func loadItemCount() int {
+	return envInt("OXICLEANARR_LOAD_ITEMS", 0)
+}
+
+// generateLoadMedia creates count empty media files in HostLoadMediaDir
+func generateLoadMedia(count int) ([]string, error) {
+	if err := os.MkdirAll(HostLoadMediaDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create load media directory: %w", err)
+	}
+
+	names := make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		name := fmt.Sprintf("Load Movie %05d (2000).mkv", i)
+		f, err := os.Create(filepath.Join(HostLoadMediaDir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", name, err)
+		}
+		f.Close()
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// TestLoad measures add/list/remove throughput and Jellyfin refresh time for a large batch.
+// It only runs when OXICLEANARR_LOAD_ITEMS is set, e.g. OXICLEANARR_LOAD_ITEMS=10000.
+func TestLoad(t *testing.T) {
+	itemCount := loadItemCount()
+	if itemCount == 0 {
+		t.Skip("Set OXICLEANARR_LOAD_ITEMS to run the load test")
+	}
+	batchSize := envInt("OXICLEANARR_LOAD_BATCH_SIZE", DefaultLoadBatchSize)
+	resultsFile := os.Getenv("OXICLEANARR_LOAD_RESULTS")
+	if resultsFile == "" {
+		resultsFile = "load-results.json"
+	}
+
+	client, err := SetupJellyfinForTest(t, JellyfinURL, AdminUsername, AdminPassword)
+	if err != nil {
+		t.Fatalf("Failed to setup Jellyfin (fail-fast): %v", err)
+	}
+
+	if err := client.UpdatePluginConfiguration(testPluginConfiguration()); err != nil {
+		t.Fatalf("Failed to configure plugin (fail-fast): %v", err)
+	}
+
+	result := LoadTestResult{Items: itemCount, BatchSize: batchSize}
+	result.JellyfinVersion, err = client.GetServerVersion()
+	if err != nil {
+		t.Fatalf("Failed to get Jellyfin server version (fail-fast): %v", err)
+	}
+
+	t.Logf("Generating %d media files...", itemCount)
+	names, err := generateLoadMedia(itemCount)
+	if err != nil {
+		t.Fatalf("Failed to generate load media (fail-fast): %v", err)
+	}
+
+	libraryName := "Leaving Soon Load"
+	loadSymlinkDir := filepath.Join(ContainerSymlinkDir, "load")
+
+	t.Cleanup(func() {
+		if shouldKeepFiles() {
+			return
+		}
+		if err := client.RemoveVirtualFolder(libraryName); err != nil {
+			t.Logf("Warning: Failed to remove library %s: %v", libraryName, err)
+		}
+		resp, err := client.DoRequest("DELETE", "/api/oxicleanarr/directories/remove", map[string]interface{}{
+			"directory": loadSymlinkDir,
+			"force":     true,
+		})
+		if err == nil {
+			resp.Body.Close()
+		}
+	})
+
+	// Add in batches
+	var created []string
+	start := time.Now()
+	for offset := 0; offset < len(names); offset += batchSize {
+		end := offset + batchSize
+		if end > len(names) {
+			end = len(names)
+		}
+
+		var items []map[string]string
+		for _, name := range names[offset:end] {
+			items = append(items, map[string]string{
+				"sourcePath":      filepath.Join(ContainerLoadMediaDir, name),
+				"targetDirectory": loadSymlinkDir,
+			})
+		}
+
+		resp, err := client.DoRequest("POST", "/api/oxicleanarr/symlinks/add", map[string]interface{}{
+			"items": items,
+		})
+		if err != nil {
+			t.Fatalf("Failed to call add symlink endpoint (fail-fast): %v", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			t.Fatalf("Add symlink returned %d, expected 200 (fail-fast)", resp.StatusCode)
+		}
+
+		var addResponse struct {
+			CreatedSymlinks []string `json:"CreatedSymlinks"`
+			Errors          []string `json:"Errors"`
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&addResponse)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("Failed to decode add response (fail-fast): %v", err)
+		}
+
+		created = append(created, addResponse.CreatedSymlinks...)
+		result.Errors += len(addResponse.Errors)
+	}
+	result.AddSeconds = time.Since(start).Seconds()
+	result.AddItemsPerSecond = float64(len(created)) / result.AddSeconds
+	t.Logf("Added %d symlinks in %.2fs (%.0f items/s)", len(created), result.AddSeconds, result.AddItemsPerSecond)
+	assert.Len(t, created, itemCount, "Should create every symlink")
+
+	// List
+	start = time.Now()
+	resp, err := client.DoRequest("GET", SymlinkListPath(loadSymlinkDir), nil)
+	if err != nil {
+		t.Fatalf("Failed to call list symlinks endpoint (fail-fast): %v", err)
+	}
+
+	var listResponse struct {
+		Count int `json:"Count"`
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	result.ListSeconds = time.Since(start).Seconds()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("List symlinks returned %d, expected 200 (fail-fast)", resp.StatusCode)
+	}
+	if err := json.Unmarshal(body, &listResponse); err != nil {
+		t.Fatalf("Failed to decode list response (fail-fast): %v", err)
+	}
+	result.ListedItems = listResponse.Count
+	t.Logf("Listed %d symlinks in %.2fs", result.ListedItems, result.ListSeconds)
+	assert.Equal(t, itemCount, result.ListedItems, "Should list every symlink")
+
+	// Refresh: time until Jellyfin has indexed every symlink
+	start = time.Now()
+	if err := client.CreateVirtualFolder(libraryName, "movies", loadSymlinkDir); err != nil {
+		t.Fatalf("Failed to create load library (fail-fast): %v", err)
+	}
+
+	libraryID, err := client.GetVirtualFolderID(libraryName)
+	if err != nil {
+		t.Fatalf("Failed to find load library (fail-fast): %v", err)
+	}
+
+	deadline := start.Add(LoadRefreshTimeout)
+	result.RefreshTimedOut = true
+	for time.Now().Before(deadline) {
+		movies, err := client.GetItems(libraryID, "Movie")
+		if err == nil {
+			result.RefreshedItems = len(movies)
+			if result.RefreshedItems >= itemCount {
+				result.RefreshTimedOut = false
+				break
+			}
+		}
+		time.Sleep(DefaultRetryDelay)
+	}
+	result.RefreshSeconds = time.Since(start).Seconds()
+	t.Logf("Jellyfin indexed %d/%d items in %.2fs", result.RefreshedItems, itemCount, result.RefreshSeconds)
+	assert.False(t, result.RefreshTimedOut, "Jellyfin should index every symlink within %s", LoadRefreshTimeout)
+	assert.Equal(t, itemCount, result.RefreshedItems, "Jellyfin should index every symlink")
+
+	// Remove in batches
+	start = time.Now()
+	removed := 0
+	for offset := 0; offset < len(created); offset += batchSize {
+		end := offset + batchSize
+		if end > len(created) {
+			end = len(created)
+		}
+
+		removeResponse := removeSymlinks(t, client, created[offset:end]...)
+		removed += len(removeResponse.RemovedSymlinks)
+		result.Errors += len(removeResponse.Errors)
+	}
+	result.RemoveSeconds = time.Since(start).Seconds()
+	result.RemoveItemsPerSecond = float64(removed) / result.RemoveSeconds
+	t.Logf("Removed %d symlinks in %.2fs (%.0f items/s)", removed, result.RemoveSeconds, result.RemoveItemsPerSecond)
+	assert.Equal(t, len(created), removed, "Should remove every symlink")
+	assert.Zero(t, result.Errors, "Should have no per-item errors")
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to encode load test results: %v", err)
+	}
+	if err := os.WriteFile(resultsFile, data, 0644); err != nil {
+		t.Fatalf("Failed to write load test results: %v", err)
+	}
+
+	t.Logf("✓ Load test results written to %s", resultsFile)
+}
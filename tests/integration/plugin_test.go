@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,22 +12,51 @@ import (
 	"testing"
 	"time"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 const (
-	JellyfinURL   = "http://localhost:8096"
 	AdminUsername = "admin"
 	AdminPassword = "adminpass"
 	// Container paths (as seen from inside Jellyfin Docker container)
 	ContainerMediaDir   = "/media/movies"
+	ContainerTVDir      = "/media/tv"
 	ContainerSymlinkDir = "/data/leaving-soon"
 	TestMovieFile       = "Test Movie (2024)/Test Movie (2024).mkv"
+	TestShowDir         = "Test Show (2024)"
 	// Host paths (for file verification outside container)
 	AssetsDir      = "../assets"
 	HostSymlinkDir = "../assets/leaving-soon-data"
+	HostConfigDir  = "../assets/jellyfin-config"
+	// ContainerConfigDir is Jellyfin's config mount, used as a path outside the allowed roots
+	ContainerConfigDir = "/config"
+	// JellyfinStartupTimeout bounds how long the container wait strategy waits for /health
+	JellyfinStartupTimeout = 3 * time.Minute
 )
 
+var (
+	// JellyfinURL is the mapped address of the Jellyfin container, set by TestMain
+	JellyfinURL string
+
+	jellyfinContainer testcontainers.Container
+)
+
+// jellyfinImageTag returns the Jellyfin Docker image tag under test (JELLYFIN_VERSION, default "latest")
+func jellyfinImageTag() string {
+	if tag := os.Getenv("JELLYFIN_VERSION"); tag != "" {
+		return tag
+	}
+	return "latest"
+}
+
+// testPluginConfiguration confines the plugin to the symlink directory, as a real deployment would
+func testPluginConfiguration() PluginConfiguration {
+	return PluginConfiguration{AllowedRoots: []string{ContainerSymlinkDir}}
+}
+
 // shouldKeepFiles returns true if cleanup should be skipped
 func shouldKeepFiles() bool {
 	return os.Getenv("OXICLEANARR_KEEP_FILES") == "1"
@@ -134,38 +164,65 @@ func InstallPluginToJellyfin() error {
 	return nil
 }
 
-// StartDockerEnvironment starts the Docker Compose environment
-func StartDockerEnvironment() error {
+// StartJellyfinContainer starts Jellyfin and waits for /health
+func StartJellyfinContainer(ctx context.Context) error {
 	absAssetsDir, err := filepath.Abs(AssetsDir)
 	if err != nil {
 		return fmt.Errorf("failed to get absolute assets dir: %w", err)
 	}
 
-	fmt.Println("Starting Docker Compose environment...")
+	image := "jellyfin/jellyfin:" + jellyfinImageTag()
+	fmt.Printf("Starting Jellyfin container (%s)...\n", image)
 
-	// Run docker-compose up -d
-	cmd := exec.Command("docker-compose", "up", "-d")
-	cmd.Dir = absAssetsDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	dirs := []string{"jellyfin-config", "jellyfin-cache", "leaving-soon-data"}
+	binds := []string{
+		filepath.Join(absAssetsDir, "jellyfin-config") + ":/config:z",
+		filepath.Join(absAssetsDir, "jellyfin-cache") + ":/cache:z",
+		filepath.Join(absAssetsDir, "test-media", "movies") + ":" + ContainerMediaDir + ":ro,z",
+		filepath.Join(absAssetsDir, "test-media", "tv") + ":" + ContainerTVDir + ":ro,z",
+		filepath.Join(absAssetsDir, "leaving-soon-data") + ":" + ContainerSymlinkDir + ":z",
+	}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to start Docker Compose: %w", err)
+	// Load media is only mounted when TestLoad will actually run
+	if loadItemCount() > 0 {
+		dirs = append(dirs, "load-media")
+		binds = append(binds, filepath.Join(absAssetsDir, "load-media")+":"+ContainerLoadMediaDir+":ro,z")
 	}
 
-	fmt.Println("Docker Compose environment started")
-	return nil
-}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(absAssetsDir, dir), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        image,
+		ExposedPorts: []string{"8096/tcp"},
+		Env:          map[string]string{"TZ": "UTC"},
+		HostConfigModifier: func(hc *container.HostConfig) {
+			hc.Binds = binds
+		},
+		WaitingFor: wait.ForHTTP("/health").
+			WithPort("8096/tcp").
+			WithStartupTimeout(JellyfinStartupTimeout),
+	}
 
-// IsDockerEnvironmentRunning checks if the Jellyfin container is running
-func IsDockerEnvironmentRunning() bool {
-	cmd := exec.Command("docker", "ps", "--filter", "name=jellyfin-test", "--format", "{{.Names}}")
-	output, err := cmd.CombinedOutput()
+	jellyfinContainer, err = testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
 	if err != nil {
-		return false
+		return fmt.Errorf("failed to start Jellyfin container: %w", err)
 	}
 
-	return len(output) > 0 && string(output) != ""
+	endpoint, err := jellyfinContainer.PortEndpoint(ctx, "8096/tcp", "http")
+	if err != nil {
+		return fmt.Errorf("failed to get Jellyfin endpoint: %w", err)
+	}
+	JellyfinURL = endpoint
+
+	fmt.Printf("Jellyfin container started at %s\n", JellyfinURL)
+	return nil
 }
 
 // TestMain runs before all tests and handles global setup/cleanup
@@ -177,18 +234,11 @@ func TestMain(m *testing.M) {
 	fmt.Println("Starting Integration Test Environment")
 	fmt.Println("============================================================")
 
-	// Stop any existing Docker environment first to ensure clean state
-	tmpT := &testing.T{}
-	if IsDockerEnvironmentRunning() {
-		fmt.Println("Stopping existing Docker environment...")
-		CleanupDockerEnvironment(tmpT)
-		// Wait for cleanup to complete
-		time.Sleep(2 * time.Second)
-	}
+	ctx := context.Background()
 
 	// Always clean up old directories before starting (forced cleanup for fresh state)
 	fmt.Println("Cleaning up old test directories...")
-	cleanupTestDirectoriesForced(tmpT)
+	cleanupTestDirectoriesForced(&testing.T{})
 
 	// Build plugin
 	if err := BuildPlugin(); err != nil {
@@ -202,16 +252,13 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
-	// Start Docker Compose environment
-	if err := StartDockerEnvironment(); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to start Docker environment: %v\n", err)
+	// Start Jellyfin container (blocks until /health responds)
+	if err := StartJellyfinContainer(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to start Jellyfin container: %v\n", err)
+		CleanupDockerEnvironment(&testing.T{})
 		os.Exit(1)
 	}
 
-	// Give Docker a moment to initialize
-	fmt.Println("Waiting for Docker containers to initialize...")
-	time.Sleep(2 * time.Second)
-
 	fmt.Println("============================================================")
 
 	// Run tests
@@ -243,6 +290,16 @@ func TestIntegration(t *testing.T) {
 	}
 	t.Logf("Jellyfin setup complete - UserID: %s", client.UserID)
 
+	serverVersion, err := client.GetServerVersion()
+	if err != nil {
+		t.Fatalf("Failed to get Jellyfin server version (fail-fast): %v", err)
+	}
+	t.Logf("Jellyfin server version: %s (image tag: %s)", serverVersion, jellyfinImageTag())
+
+	if err := client.UpdatePluginConfiguration(testPluginConfiguration()); err != nil {
+		t.Fatalf("Failed to configure plugin (fail-fast): %v", err)
+	}
+
 	// Cleanup test environment at the end
 	t.Cleanup(func() {
 		t.Logf("Cleaning up test symlinks...")
@@ -264,7 +321,8 @@ func TestIntegration(t *testing.T) {
 		}
 
 		var status struct {
-			Version string `json:"Version"`
+			Version      string   `json:"Version"`
+			Capabilities []string `json:"Capabilities"`
 		}
 
 		err = json.NewDecoder(resp.Body).Decode(&status)
@@ -274,8 +332,12 @@ func TestIntegration(t *testing.T) {
 
 		t.Logf("✓ Status endpoint accessible without authentication")
 		t.Logf("  Plugin version: %s", status.Version)
+		t.Logf("  Capabilities: %v", status.Capabilities)
 		assert.NotEmpty(t, status.Version, "Version should not be empty")
 		assert.Contains(t, status.Version, "3.2.1", "Expected v3.2.1")
+		for _, capability := range []string{"symlinks_add", "symlinks_remove", "symlinks_list", "symlink_names", "symlink_only_removal", "allowed_roots", "allowed_source_roots"} {
+			assert.Contains(t, status.Capabilities, capability, "Status should advertise %s", capability)
+		}
 
 		// Also verify that status endpoint works WITH authentication
 		t.Logf("Testing status endpoint with authentication...")
@@ -307,7 +369,7 @@ func TestIntegration(t *testing.T) {
 		t.Logf("Testing that non-status endpoints require authentication...")
 
 		// Try to list symlinks without authentication
-		resp, err := http.Get(fmt.Sprintf("%s/api/oxicleanarr/symlinks/list?directory=%s", JellyfinURL, ContainerSymlinkDir))
+		resp, err := http.Get(JellyfinURL + SymlinkListPath(ContainerSymlinkDir))
 		if err != nil {
 			t.Fatalf("Failed to call list endpoint (fail-fast): %v", err)
 		}
@@ -324,7 +386,7 @@ func TestIntegration(t *testing.T) {
 		t.Logf("✓ List endpoint correctly requires authentication (got %d)", resp.StatusCode)
 
 		// Verify it works WITH authentication
-		resp2, err := client.DoRequest("GET", fmt.Sprintf("/api/oxicleanarr/symlinks/list?directory=%s", ContainerSymlinkDir), nil)
+		resp2, err := client.DoRequest("GET", SymlinkListPath(ContainerSymlinkDir), nil)
 		if err != nil {
 			t.Fatalf("Failed to call list endpoint with auth (fail-fast): %v", err)
 		}
@@ -446,7 +508,7 @@ func TestIntegration(t *testing.T) {
 		t.Logf("Testing symlink listing...")
 
 		// List symlinks via API using container path
-		resp, err := client.DoRequest("GET", fmt.Sprintf("/api/oxicleanarr/symlinks/list?directory=%s", symlinkDir), nil)
+		resp, err := client.DoRequest("GET", SymlinkListPath(symlinkDir), nil)
 		if err != nil {
 			t.Fatalf("Failed to call list symlinks endpoint (fail-fast): %v", err)
 		}
@@ -551,7 +613,7 @@ func TestIntegration(t *testing.T) {
 		t.Logf("Testing list on empty directory...")
 
 		// List symlinks in now-empty directory using container path
-		resp, err := client.DoRequest("GET", fmt.Sprintf("/api/oxicleanarr/symlinks/list?directory=%s", symlinkDir), nil)
+		resp, err := client.DoRequest("GET", SymlinkListPath(symlinkDir), nil)
 		if err != nil {
 			t.Fatalf("Failed to call list symlinks endpoint (fail-fast): %v", err)
 		}
@@ -648,7 +710,7 @@ func TestIntegration(t *testing.T) {
 
 		// List and verify all symlinks using container path
 		t.Logf("Listing symlinks to verify all were created...")
-		resp, err = client.DoRequest("GET", fmt.Sprintf("/api/oxicleanarr/symlinks/list?directory=%s", symlinkDir), nil)
+		resp, err = client.DoRequest("GET", SymlinkListPath(symlinkDir), nil)
 		if err != nil {
 			t.Fatalf("Failed to call list symlinks endpoint (fail-fast): %v", err)
 		}
@@ -705,6 +767,543 @@ func TestIntegration(t *testing.T) {
 			assert.Contains(t, symlink.Target, ContainerMediaDir, "Symlink target should be in media directory")
 		}
 	})
+
+	// Test 7: Directory names with spaces and unicode
+	t.Run("UnicodeAndSpacesInPaths", func(t *testing.T) {
+		t.Logf("Testing symlink operations in a directory with spaces and unicode...")
+
+		subdir := "Leaving Soon – Ünïcödé & Co"
+		unicodeDir := filepath.Join(symlinkDir, subdir)
+
+		payload := map[string]interface{}{
+			"items": []map[string]string{
+				{
+					"sourcePath":      sourceFile,
+					"targetDirectory": unicodeDir,
+				},
+			},
+		}
+
+		resp, err := client.DoRequest("POST", "/api/oxicleanarr/symlinks/add", payload)
+		if err != nil {
+			t.Fatalf("Failed to call add symlink endpoint (fail-fast): %v", err)
+		}
+		defer resp.Body.Close()
+
+		var addResponse struct {
+			Success         bool     `json:"Success"`
+			CreatedSymlinks []string `json:"CreatedSymlinks"`
+			Errors          []string `json:"Errors"`
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		t.Logf("Add response: %s", string(body))
+		if err := json.Unmarshal(body, &addResponse); err != nil {
+			t.Fatalf("Failed to decode add response (fail-fast): %v", err)
+		}
+
+		assert.Empty(t, addResponse.Errors, "Should have no errors")
+		assert.Contains(t, addResponse.CreatedSymlinks, filepath.Join(unicodeDir, "Test Movie (2024).mkv"))
+
+		hostSymlinkPath := filepath.Join(HostSymlinkDir, subdir, "Test Movie (2024).mkv")
+		if _, err := os.Lstat(hostSymlinkPath); err != nil {
+			t.Errorf("Symlink should exist on filesystem: %s (error: %v)", hostSymlinkPath, err)
+		}
+
+		// List with the directory query-encoded
+		resp, err = client.DoRequest("GET", SymlinkListPath(unicodeDir), nil)
+		if err != nil {
+			t.Fatalf("Failed to call list symlinks endpoint (fail-fast): %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("List symlinks returned %d, expected 200 (fail-fast)", resp.StatusCode)
+		}
+
+		var listResponse struct {
+			Count        int      `json:"Count"`
+			SymlinkNames []string `json:"SymlinkNames"`
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&listResponse); err != nil {
+			t.Fatalf("Failed to decode list response (fail-fast): %v", err)
+		}
+
+		assert.Equal(t, 1, listResponse.Count, "Should list 1 symlink")
+		assert.Contains(t, listResponse.SymlinkNames, "Test Movie (2024).mkv")
+
+		// Remove the symlink by its unicode path
+		unicodeSymlink := filepath.Join(unicodeDir, "Test Movie (2024).mkv")
+		removeResponse := removeSymlinks(t, client, unicodeSymlink)
+		assert.Empty(t, removeResponse.Errors, "Should have no errors")
+		assert.Contains(t, removeResponse.RemovedSymlinks, unicodeSymlink)
+
+		if _, err := os.Lstat(hostSymlinkPath); !os.IsNotExist(err) {
+			t.Errorf("Symlink should be removed from filesystem: %s", hostSymlinkPath)
+		}
+
+		// Remove the now empty directory
+		resp, err = client.DoRequest("DELETE", "/api/oxicleanarr/directories/remove", map[string]interface{}{
+			"directory": unicodeDir,
+			"force":     true,
+		})
+		if err != nil {
+			t.Fatalf("Failed to call remove directory endpoint (fail-fast): %v", err)
+		}
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Remove directory should return 200")
+
+		t.Logf("✓ Paths with spaces and unicode handled correctly")
+	})
+
+	// Test 8: TV show with multiple seasons and specials
+	t.Run("TVShowSeasons", func(t *testing.T) {
+		t.Logf("Testing TV show symlinks grouped by season...")
+
+		libraryName := "Leaving Soon TV"
+		tvSymlinkDir := filepath.Join(symlinkDir, "tv")
+		showSymlinkDir := filepath.Join(tvSymlinkDir, TestShowDir)
+
+		// Season folder -> episode filenames (mirrors tests/assets/test-media/tv)
+		seasons := map[string][]string{
+			"Season 01": {"Test Show (2024) - S01E01.mkv", "Test Show (2024) - S01E02.mkv"},
+			"Season 02": {"Test Show (2024) - S02E01.mkv"},
+			"Specials":  {"Test Show (2024) - S00E01.mkv"},
+		}
+
+		var items []map[string]string
+		for season, episodes := range seasons {
+			for _, episode := range episodes {
+				items = append(items, map[string]string{
+					"sourcePath":      filepath.Join(ContainerTVDir, TestShowDir, season, episode),
+					"targetDirectory": filepath.Join(showSymlinkDir, season),
+				})
+			}
+		}
+
+		t.Cleanup(func() {
+			if shouldKeepFiles() {
+				return
+			}
+			if err := client.RemoveVirtualFolder(libraryName); err != nil {
+				t.Logf("Warning: Failed to remove library %s: %v", libraryName, err)
+			}
+			resp, err := client.DoRequest("DELETE", "/api/oxicleanarr/directories/remove", map[string]interface{}{
+				"directory": tvSymlinkDir,
+				"force":     true,
+			})
+			if err != nil {
+				t.Logf("Warning: Failed to remove %s: %v", tvSymlinkDir, err)
+				return
+			}
+			resp.Body.Close()
+		})
+
+		resp, err := client.DoRequest("POST", "/api/oxicleanarr/symlinks/add", map[string]interface{}{
+			"items": items,
+		})
+		if err != nil {
+			t.Fatalf("Failed to call add symlink endpoint (fail-fast): %v", err)
+		}
+		defer resp.Body.Close()
+
+		var addResponse struct {
+			Success         bool     `json:"Success"`
+			CreatedSymlinks []string `json:"CreatedSymlinks"`
+			Errors          []string `json:"Errors"`
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		t.Logf("Add response: %s", string(body))
+		if err := json.Unmarshal(body, &addResponse); err != nil {
+			t.Fatalf("Failed to decode add response (fail-fast): %v", err)
+		}
+
+		if !assert.Empty(t, addResponse.Errors, "Should have no errors") {
+			t.FailNow()
+		}
+		assert.Len(t, addResponse.CreatedSymlinks, len(items), "Should create one symlink per episode")
+
+		// Each season folder should list exactly its own episodes
+		for season, episodes := range seasons {
+			resp, err := client.DoRequest("GET", SymlinkListPath(filepath.Join(showSymlinkDir, season)), nil)
+			if err != nil {
+				t.Fatalf("Failed to call list symlinks endpoint (fail-fast): %v", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				resp.Body.Close()
+				t.Fatalf("List symlinks for %s returned %d, expected 200 (fail-fast)", season, resp.StatusCode)
+			}
+
+			var listResponse struct {
+				Count        int      `json:"Count"`
+				SymlinkNames []string `json:"SymlinkNames"`
+			}
+
+			err = json.NewDecoder(resp.Body).Decode(&listResponse)
+			resp.Body.Close()
+			if err != nil {
+				t.Fatalf("Failed to decode list response (fail-fast): %v", err)
+			}
+
+			assert.Equal(t, len(episodes), listResponse.Count, "Season %s should list %d symlinks", season, len(episodes))
+			assert.ElementsMatch(t, episodes, listResponse.SymlinkNames, "Season %s symlink names", season)
+		}
+
+		// Point a TV library at the symlinks and check Jellyfin groups the episodes by season
+		if err := client.CreateVirtualFolder(libraryName, "tvshows", tvSymlinkDir); err != nil {
+			t.Fatalf("Failed to create TV library (fail-fast): %v", err)
+		}
+
+		libraryID, err := client.GetVirtualFolderID(libraryName)
+		if err != nil {
+			t.Fatalf("Failed to find TV library (fail-fast): %v", err)
+		}
+
+		var episodes []Item
+		deadline := time.Now().Add(2 * time.Minute)
+		for time.Now().Before(deadline) {
+			episodes, err = client.GetItems(libraryID, "Episode")
+			if err == nil && len(episodes) == len(items) {
+				break
+			}
+			time.Sleep(DefaultRetryDelay)
+		}
+
+		if !assert.Len(t, episodes, len(items), "Jellyfin should index every symlinked episode") {
+			t.FailNow()
+		}
+
+		episodesPerSeason := map[int]int{}
+		for _, episode := range episodes {
+			assert.Contains(t, episode.SeriesName, "Test Show", "Episode should belong to the test show")
+			// A missing season number would otherwise decode as 0 and be counted as a special
+			if !assert.NotNil(t, episode.ParentIndexNumber, "Episode %q should have a season number", episode.Name) {
+				continue
+			}
+			season := *episode.ParentIndexNumber
+			t.Logf("  %s S%02dE%02d - %s", episode.SeriesName, season, episode.IndexNumber, episode.Name)
+			episodesPerSeason[season]++
+		}
+
+		assert.Equal(t, map[int]int{0: 1, 1: 2, 2: 1}, episodesPerSeason, "Episodes should be grouped into specials, season 1 and season 2")
+
+		series, err := client.GetItems(libraryID, "Series")
+		if err != nil {
+			t.Fatalf("Failed to query series (fail-fast): %v", err)
+		}
+		assert.Len(t, series, 1, "All episodes should be grouped under a single series")
+
+		t.Logf("✓ TV show episodes grouped correctly across %d seasons", len(episodesPerSeason))
+	})
+
+	// Test 9: Path traversal and non-symlink removal are refused
+	// (relies on the symlinks left in place by MultipleSymlinks)
+	existingSymlink := filepath.Join(symlinkDir, "Test Movie (2024).mkv")
+	hostExistingSymlink := filepath.Join(HostSymlinkDir, "Test Movie (2024).mkv")
+
+	t.Run("RemoveRejectsParentSegments", func(t *testing.T) {
+		// Resolves to existingSymlink; string concatenation keeps the ".." that filepath.Join would clean
+		traversalPath := symlinkDir + "/../" + filepath.Base(symlinkDir) + "/" + filepath.Base(existingSymlink)
+		t.Logf("Removing %s", traversalPath)
+
+		removeResponse := removeSymlinks(t, client, traversalPath)
+
+		assert.Empty(t, removeResponse.RemovedSymlinks, "Traversal path should not be removed")
+		assertErrorFor(t, removeResponse.Errors, traversalPath)
+
+		_, err := os.Lstat(hostExistingSymlink)
+		assert.NoError(t, err, "Symlink reachable through '..' should still exist")
+		t.Logf("✓ '..' path rejected: %v", removeResponse.Errors)
+	})
+
+	t.Run("RemoveRejectsRelativePath", func(t *testing.T) {
+		relativePath := "leaving-soon/Test Movie (2024).mkv"
+
+		removeResponse := removeSymlinks(t, client, relativePath)
+
+		assert.Empty(t, removeResponse.RemovedSymlinks, "Relative path should not be removed")
+		assertErrorFor(t, removeResponse.Errors, relativePath)
+		t.Logf("✓ Relative path rejected: %v", removeResponse.Errors)
+	})
+
+	t.Run("RemoveRejectsRegularFile", func(t *testing.T) {
+		// A regular file inside the allowed root, so only the symlink check can refuse it
+		hostRegularFile := filepath.Join(HostSymlinkDir, "regular-file.mkv")
+		regularFile := filepath.Join(symlinkDir, "regular-file.mkv")
+		if err := os.WriteFile(hostRegularFile, nil, 0644); err != nil {
+			t.Fatalf("Failed to create regular file (fail-fast): %v", err)
+		}
+		t.Cleanup(func() { os.Remove(hostRegularFile) })
+
+		removeResponse := removeSymlinks(t, client, regularFile)
+
+		assert.Empty(t, removeResponse.RemovedSymlinks, "Regular file should not be removed")
+		assertErrorFor(t, removeResponse.Errors, regularFile)
+		_, err := os.Stat(hostRegularFile)
+		assert.NoError(t, err, "Regular file should still exist")
+		t.Logf("✓ Regular file rejected: %v", removeResponse.Errors)
+	})
+
+	t.Run("RemoveRejectsDirectory", func(t *testing.T) {
+		removeResponse := removeSymlinks(t, client, symlinkDir)
+
+		assert.Empty(t, removeResponse.RemovedSymlinks, "Directory should not be reported as a removed symlink")
+		assertErrorFor(t, removeResponse.Errors, symlinkDir)
+		t.Logf("✓ Directory rejected: %v", removeResponse.Errors)
+	})
+
+	t.Run("RemoveDirectoryRejectsParentSegments", func(t *testing.T) {
+		guardDir := filepath.Join(symlinkDir, "traversal-guard")
+		hostGuardDir := filepath.Join(HostSymlinkDir, "traversal-guard")
+
+		resp, err := client.DoRequest("POST", "/api/oxicleanarr/directories/create", map[string]interface{}{
+			"directory": guardDir,
+		})
+		if err != nil {
+			t.Fatalf("Failed to call create directory endpoint (fail-fast): %v", err)
+		}
+		resp.Body.Close()
+
+		t.Cleanup(func() {
+			resp, err := client.DoRequest("DELETE", "/api/oxicleanarr/directories/remove", map[string]interface{}{
+				"directory": guardDir,
+				"force":     true,
+			})
+			if err == nil {
+				resp.Body.Close()
+			}
+		})
+
+		traversalDir := guardDir + "/../traversal-guard"
+		resp, err = client.DoRequest("DELETE", "/api/oxicleanarr/directories/remove", map[string]interface{}{
+			"directory": traversalDir,
+			"force":     true,
+		})
+		if err != nil {
+			t.Fatalf("Failed to call remove directory endpoint (fail-fast): %v", err)
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		t.Logf("Remove directory response: %s", string(body))
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Traversal directory should be rejected with 400")
+		_, err = os.Stat(hostGuardDir)
+		assert.NoError(t, err, "Directory reachable through '..' should still exist")
+		t.Logf("✓ '..' directory path rejected")
+	})
+
+	// Test 10: Absolute paths outside the allowed root are refused, including through a symlinked parent.
+	// Fixtures are created on the host in Jellyfin's config mount, which is outside the root.
+	hostOutsideSymlink := filepath.Join(HostConfigDir, "oxicleanarr-outside.mkv")
+	outsideSymlink := filepath.Join(ContainerConfigDir, "oxicleanarr-outside.mkv")
+	hostOutsideDir := filepath.Join(HostConfigDir, "oxicleanarr-guard")
+	outsideDir := filepath.Join(ContainerConfigDir, "oxicleanarr-guard")
+	hostEscapeLink := filepath.Join(HostSymlinkDir, "escape")
+	escapeDir := filepath.Join(symlinkDir, "escape")
+
+	if err := os.Symlink(sourceFile, hostOutsideSymlink); err != nil {
+		t.Fatalf("Failed to create symlink outside the root (fail-fast): %v", err)
+	}
+	if err := os.MkdirAll(hostOutsideDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory outside the root (fail-fast): %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(hostOutsideDir, "keep.txt"), nil, 0644); err != nil {
+		t.Fatalf("Failed to create file outside the root (fail-fast): %v", err)
+	}
+	// Resolved inside the container, where it points at the config mount
+	if err := os.Symlink(ContainerConfigDir, hostEscapeLink); err != nil {
+		t.Fatalf("Failed to create escaping symlink (fail-fast): %v", err)
+	}
+	t.Cleanup(func() {
+		os.Remove(hostEscapeLink)
+		os.Remove(hostOutsideSymlink)
+		os.RemoveAll(hostOutsideDir)
+	})
+
+	t.Run("RemoveRejectsOutsideRoot", func(t *testing.T) {
+		for _, path := range []string{outsideSymlink, sourceFile, filepath.Join(escapeDir, "oxicleanarr-outside.mkv")} {
+			removeResponse := removeSymlinks(t, client, path)
+
+			assert.Empty(t, removeResponse.RemovedSymlinks, "Path outside the root should not be removed: %s", path)
+			assertErrorFor(t, removeResponse.Errors, path)
+			if len(removeResponse.Errors) == 1 {
+				assert.Contains(t, removeResponse.Errors[0], "outside the allowed roots")
+			}
+		}
+
+		_, err := os.Lstat(hostOutsideSymlink)
+		assert.NoError(t, err, "Symlink outside the root should still exist")
+		t.Logf("✓ Paths outside the root rejected")
+	})
+
+	t.Run("DirectoriesRejectOutsideRoot", func(t *testing.T) {
+		for _, dir := range []string{outsideDir, filepath.Join(escapeDir, "oxicleanarr-guard"), symlinkDir} {
+			status := requestStatus(t, client, "DELETE", "/api/oxicleanarr/directories/remove", map[string]interface{}{
+				"directory": dir,
+				"force":     true,
+			})
+			assert.Equal(t, http.StatusBadRequest, status, "Removing %s should be rejected with 400", dir)
+		}
+
+		status := requestStatus(t, client, "POST", "/api/oxicleanarr/directories/create", map[string]interface{}{
+			"directory": filepath.Join(ContainerConfigDir, "oxicleanarr-created"),
+		})
+		assert.Equal(t, http.StatusBadRequest, status, "Creating a directory outside the root should be rejected with 400")
+
+		status = requestStatus(t, client, "GET", SymlinkListPath(ContainerMediaDir), nil)
+		assert.Equal(t, http.StatusBadRequest, status, "Listing outside the root should be rejected with 400")
+
+		_, err := os.Stat(filepath.Join(hostOutsideDir, "keep.txt"))
+		assert.NoError(t, err, "Directory outside the root should still exist")
+		_, err = os.Stat(HostSymlinkDir)
+		assert.NoError(t, err, "Allowed root should still exist")
+		_, err = os.Stat(filepath.Join(HostConfigDir, "oxicleanarr-created"))
+		assert.True(t, os.IsNotExist(err), "Directory outside the root should not be created")
+		t.Logf("✓ Directory operations outside the root rejected")
+	})
+
+	t.Run("AddRejectsSourceOutsideSourceRoots", func(t *testing.T) {
+		config := testPluginConfiguration()
+		config.AllowedSourceRoots = []string{ContainerMediaDir, ContainerTVDir}
+		if err := client.UpdatePluginConfiguration(config); err != nil {
+			t.Fatalf("Failed to set allowed source roots (fail-fast): %v", err)
+		}
+		t.Cleanup(func() {
+			if err := client.UpdatePluginConfiguration(testPluginConfiguration()); err != nil {
+				t.Errorf("Failed to restore plugin configuration: %v", err)
+			}
+		})
+
+		sourceDir := filepath.Join(symlinkDir, "source-roots")
+		t.Cleanup(func() {
+			resp, err := client.DoRequest("DELETE", "/api/oxicleanarr/directories/remove", map[string]interface{}{
+				"directory": sourceDir,
+				"force":     true,
+			})
+			if err == nil {
+				resp.Body.Close()
+			}
+		})
+
+		// Sources are rejected before the file existence check, so the config file need not exist
+		rejected := []string{"/etc/passwd", filepath.Join(ContainerConfigDir, "config", "system.xml")}
+		var items []map[string]string
+		for _, source := range append([]string{sourceFile}, rejected...) {
+			items = append(items, map[string]string{
+				"sourcePath":      source,
+				"targetDirectory": sourceDir,
+			})
+		}
+
+		resp, err := client.DoRequest("POST", "/api/oxicleanarr/symlinks/add", map[string]interface{}{
+			"items": items,
+		})
+		if err != nil {
+			t.Fatalf("Failed to call add symlink endpoint (fail-fast): %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Add symlink returned %d, expected 200 (fail-fast)", resp.StatusCode)
+		}
+
+		var addResponse struct {
+			CreatedSymlinks []string `json:"CreatedSymlinks"`
+			Errors          []string `json:"Errors"`
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&addResponse); err != nil {
+			t.Fatalf("Failed to decode add response (fail-fast): %v", err)
+		}
+		t.Logf("Add errors: %v", addResponse.Errors)
+
+		assert.Equal(t, []string{filepath.Join(sourceDir, "Test Movie (2024).mkv")}, addResponse.CreatedSymlinks, "Only the source inside a source root should be linked")
+		if assert.Len(t, addResponse.Errors, len(rejected), "Each source outside the roots should be reported") {
+			for i, source := range rejected {
+				assert.Contains(t, addResponse.Errors[i], source)
+				assert.Contains(t, addResponse.Errors[i], "outside the allowed source roots")
+			}
+		}
+
+		_, err = os.Lstat(filepath.Join(HostSymlinkDir, "source-roots", "passwd"))
+		assert.True(t, os.IsNotExist(err), "No symlink to /etc/passwd should be created")
+		t.Logf("✓ Sources outside the allowed source roots rejected")
+	})
+
+	t.Run("RejectsWhenNoRootsConfigured", func(t *testing.T) {
+		if err := client.UpdatePluginConfiguration(PluginConfiguration{}); err != nil {
+			t.Fatalf("Failed to clear plugin configuration (fail-fast): %v", err)
+		}
+		t.Cleanup(func() {
+			if err := client.UpdatePluginConfiguration(testPluginConfiguration()); err != nil {
+				t.Errorf("Failed to restore plugin configuration: %v", err)
+			}
+		})
+
+		status := requestStatus(t, client, "GET", SymlinkListPath(symlinkDir), nil)
+		assert.Equal(t, http.StatusBadRequest, status, "Listing should be rejected without allowed roots")
+		t.Logf("✓ Requests rejected without allowed roots")
+	})
+}
+
+// requestStatus performs a request, logs the response body and returns the status code
+func requestStatus(t *testing.T, client *JellyfinClient, method, path string, body interface{}) int {
+	t.Helper()
+
+	resp, err := client.DoRequest(method, path, body)
+	if err != nil {
+		t.Fatalf("Failed to call %s %s (fail-fast): %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	t.Logf("%s %s -> %d: %s", method, path, resp.StatusCode, string(respBody))
+	return resp.StatusCode
+}
+
+// removeResponse is the decoded body of POST /api/oxicleanarr/symlinks/remove
+type removeResponse struct {
+	Success         bool     `json:"Success"`
+	RemovedSymlinks []string `json:"RemovedSymlinks"`
+	Errors          []string `json:"Errors"`
+}
+
+// removeSymlinks calls the remove endpoint and decodes the response, failing the test on transport errors
+func removeSymlinks(t *testing.T, client *JellyfinClient, paths ...string) removeResponse {
+	t.Helper()
+
+	resp, err := client.DoRequest("POST", "/api/oxicleanarr/symlinks/remove", map[string]interface{}{
+		"symlinkPaths": paths,
+	})
+	if err != nil {
+		t.Fatalf("Failed to call remove symlink endpoint (fail-fast): %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Remove symlink returned %d, expected 200 (fail-fast)", resp.StatusCode)
+	}
+
+	var response removeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode remove response (fail-fast): %v", err)
+	}
+
+	return response
+}
+
+// assertErrorFor checks that exactly one error was reported and that it names the given path
+func assertErrorFor(t *testing.T, errors []string, path string) {
+	t.Helper()
+
+	if assert.Len(t, errors, 1, "Should report one error") {
+		assert.Contains(t, errors[0], path, "Error should name the rejected path")
+	}
 }
 
 // CleanupTestSymlinks removes all test symlinks
@@ -715,7 +1314,7 @@ func CleanupTestSymlinks(t *testing.T, client *JellyfinClient) {
 	}
 
 	// List all symlinks using container path
-	resp, err := client.DoRequest("GET", fmt.Sprintf("/api/oxicleanarr/symlinks/list?directory=%s", ContainerSymlinkDir), nil)
+	resp, err := client.DoRequest("GET", SymlinkListPath(ContainerSymlinkDir), nil)
 	if err != nil {
 		t.Logf("Warning: Failed to list symlinks during cleanup: %v", err)
 		return
@@ -758,29 +1357,21 @@ func CleanupTestSymlinks(t *testing.T, client *JellyfinClient) {
 	t.Logf("Cleaned up %d symlink(s)", len(paths))
 }
 
-// CleanupDockerEnvironment stops and removes Docker containers
+// CleanupDockerEnvironment terminates the Jellyfin container
 func CleanupDockerEnvironment(t *testing.T) {
 	if shouldKeepFiles() {
 		t.Logf("Skipping Docker cleanup (OXICLEANARR_KEEP_FILES=1)")
 		return
 	}
 
-	t.Logf("Stopping Docker environment...")
+	ctx := context.Background()
 
-	absAssetsDir, err := filepath.Abs(AssetsDir)
-	if err != nil {
-		t.Logf("Warning: Failed to get absolute assets dir: %v", err)
-		return
-	}
-
-	// Run docker-compose down
-	cmd := exec.Command("docker-compose", "down", "-v")
-	cmd.Dir = absAssetsDir
-	output, err := cmd.CombinedOutput()
-
-	if err != nil {
-		t.Logf("Warning: Failed to stop Docker environment: %v\nOutput: %s", err, string(output))
-		return
+	if jellyfinContainer != nil {
+		t.Logf("Terminating Jellyfin container...")
+		if err := jellyfinContainer.Terminate(ctx); err != nil {
+			t.Logf("Warning: Failed to terminate Jellyfin container: %v", err)
+		}
+		jellyfinContainer = nil
 	}
 
 	t.Logf("Docker environment stopped and removed")
@@ -808,6 +1399,7 @@ func cleanupTestDirectoriesForced(t *testing.T) {
 		filepath.Join(absAssetsDir, "jellyfin-config"),
 		filepath.Join(absAssetsDir, "jellyfin-cache"),
 		filepath.Join(absAssetsDir, "leaving-soon-data"),
+		filepath.Join(absAssetsDir, "load-media"),
 	}
 
 	for _, dir := range dirsToRemove {
@@ -833,9 +1425,10 @@ func cleanupTestDirectoriesForced(t *testing.T) {
 func CleanupAll(t *testing.T) {
 	if shouldKeepFiles() {
 		t.Logf("OXICLEANARR_KEEP_FILES=1 - Keeping all test files and containers")
+		t.Logf("Jellyfin is still running at %s", JellyfinURL)
 		t.Logf("To manually clean up:")
-		t.Logf("  cd tests/assets && docker-compose down -v")
-		t.Logf("  rm -rf tests/assets/jellyfin-config tests/assets/jellyfin-cache tests/assets/leaving-soon-data")
+		t.Logf("  docker rm -f <container> (see docker ps)")
+		t.Logf("  rm -rf tests/assets/jellyfin-config tests/assets/jellyfin-cache tests/assets/leaving-soon-data tests/assets/load-media")
 		return
 	}
 
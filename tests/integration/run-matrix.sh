@@ -0,0 +1,57 @@
+#!/bin/bash
+set -uo pipefail
+
+# Runs the integration suite once per Jellyfin Docker image tag and prints a
+# compatibility table. Tags can be passed as arguments or via JELLYFIN_VERSIONS.
+# The plugin targets ABI 10.11.0.0, so only 10.11 and newer servers can load it.
+#
+#   ./run-matrix.sh
+#   ./run-matrix.sh 10.11.1 unstable
+#   JELLYFIN_VERSIONS="10.11.0 10.11.1" ./run-matrix.sh
+
+cd "$(dirname "$0")"
+
+if [ $# -gt 0 ]; then
+    VERSIONS=("$@")
+else
+    read -r -a VERSIONS <<< "${JELLYFIN_VERSIONS:-10.11.0 10.11.1 unstable}"
+fi
+
+RESULTS_FILE="${RESULTS_FILE:-matrix-results.md}"
+LOG_DIR="${LOG_DIR:-matrix-logs}"
+mkdir -p "${LOG_DIR}"
+
+declare -A RESULTS
+declare -A SERVER_VERSIONS
+FAILED=0
+
+for version in "${VERSIONS[@]}"; do
+    echo "============================================================"
+    echo "Jellyfin ${version}"
+    echo "============================================================"
+
+    log_file="${LOG_DIR}/jellyfin-${version}.log"
+    if JELLYFIN_VERSION="${version}" go test -v -count=1 ./... 2>&1 | tee "${log_file}"; then
+        RESULTS[$version]="PASS"
+    else
+        RESULTS[$version]="FAIL"
+        FAILED=1
+    fi
+
+    server_version=$(grep -o 'Jellyfin server version: [^ ]*' "${log_file}" | head -n 1 | awk '{print $4}')
+    SERVER_VERSIONS[$version]="${server_version:-unknown}"
+done
+
+{
+    echo "| Image tag | Server version | Result |"
+    echo "|-----------|----------------|--------|"
+    for version in "${VERSIONS[@]}"; do
+        echo "| ${version} | ${SERVER_VERSIONS[$version]} | ${RESULTS[$version]} |"
+    done
+} > "${RESULTS_FILE}"
+
+echo ""
+echo "Compatibility matrix (logs in ${LOG_DIR}/):"
+cat "${RESULTS_FILE}"
+
+exit ${FAILED}